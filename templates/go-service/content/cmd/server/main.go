@@ -0,0 +1,126 @@
+// Command server is the entrypoint for the scaffolded service.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	_ "github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/${{ values.repoUrl | parseRepoUrl | pick('owner') }}/${{ values.name }}/internal/config"
+	httpserver "github.com/${{ values.repoUrl | parseRepoUrl | pick('owner') }}/${{ values.name }}/internal/http"
+	"github.com/${{ values.repoUrl | parseRepoUrl | pick('owner') }}/${{ values.name }}/internal/migrate"
+	"github.com/${{ values.repoUrl | parseRepoUrl | pick('owner') }}/${{ values.name }}/pkg/changeset"
+	"github.com/${{ values.repoUrl | parseRepoUrl | pick('owner') }}/${{ values.name }}/pkg/ghexporter"
+	"github.com/${{ values.repoUrl | parseRepoUrl | pick('owner') }}/${{ values.name }}/pkg/selfupdate"
+)
+
+const platformRulesPath = "platform.yaml"
+
+func main() {
+	log, err := zap.NewProduction()
+	if err != nil {
+		panic(err)
+	}
+	defer log.Sync()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("load config", zap.Error(err))
+	}
+
+	if cfg.SentryDSN != "" {
+		if err := sentry.Init(sentry.ClientOptions{Dsn: cfg.SentryDSN, Environment: cfg.Environment}); err != nil {
+			log.Error("sentry init failed", zap.Error(err))
+		}
+		defer sentry.Flush(2 * time.Second)
+	}
+
+	db, err := sql.Open("postgres", cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal("open database", zap.Error(err))
+	}
+	defer db.Close()
+
+	rules, err := changeset.LoadRules(platformRulesPath)
+	if err != nil {
+		log.Fatal("load platform.yaml", zap.Error(err))
+	}
+	changesetStore := changeset.NewStore(db)
+	migrationRunner := migrate.NewRunner(cfg.DatabaseURL, "migrations", ".", changesetStore, log)
+	if err := migrationRunner.Up(context.Background(), rules); err != nil {
+		log.Fatal("run migrations", zap.Error(err))
+	}
+
+	srv := httpserver.New(cfg, log)
+
+	suCfg, err := selfupdate.LoadConfig()
+	if err != nil {
+		log.Fatal("load selfupdate config", zap.Error(err))
+	}
+	updater := selfupdate.New(suCfg, log)
+	updater.RegisterRoutes(srv.Engine())
+
+	ghCfg, err := ghexporter.LoadConfig()
+	if err != nil {
+		log.Fatal("load ghexporter config", zap.Error(err))
+	}
+	exporter := ghexporter.New(ghCfg, log)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	updater.Start(ctx)
+	exporter.Start(ctx)
+	srv.Start()
+	log.Info("service started", zap.String("version", config.Version))
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				reloadChangesetGatedSubsystems(ctx, log, migrationRunner)
+			}
+		}
+	}()
+
+	<-ctx.Done()
+	log.Info("shutting down")
+
+	updater.Stop()
+	exporter.Stop()
+	if err := srv.Stop(context.Background()); err != nil {
+		log.Error("http server shutdown", zap.Error(err))
+	}
+}
+
+// reloadChangesetGatedSubsystems re-reads platform.yaml, since a deploy
+// that ships rule changes and signals SIGHUP instead of restarting
+// should still pick them up, then re-runs migrate.Up - the only
+// platform.yaml subsystem this service can actually reload in place. Up
+// also logs when the http or metrics globs matched, since gin route and
+// Prometheus collector re-registration aren't implemented and those
+// changes still require a restart.
+func reloadChangesetGatedSubsystems(ctx context.Context, log *zap.Logger, migrationRunner *migrate.Runner) {
+	log.Info("sighup received, re-evaluating changeset rules")
+
+	rules, err := changeset.LoadRules(platformRulesPath)
+	if err != nil {
+		log.Error("changeset: reload platform.yaml", zap.Error(err))
+		return
+	}
+
+	if err := migrationRunner.Up(ctx, rules); err != nil {
+		log.Error("changeset: reload migrations", zap.Error(err))
+	}
+}
@@ -0,0 +1,34 @@
+// Package config centralizes process configuration for the scaffolded
+// service. Settings are loaded from the environment via envconfig; the
+// build-time version is injected separately through ldflags.
+package config
+
+import (
+	"fmt"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Version is the service's semver, overridden at build time via:
+//
+//	go build -ldflags "-X .../internal/config.Version=v1.2.3"
+var Version = "dev"
+
+// Spec holds the top-level environment-driven configuration for the
+// service. Subsystems that need their own envconfig prefix (selfupdate,
+// ghexporter, ...) load independently rather than nesting here.
+type Spec struct {
+	Port        int    `envconfig:"PORT" default:"8080"`
+	DatabaseURL string `envconfig:"DATABASE_URL" required:"true"`
+	Environment string `envconfig:"ENVIRONMENT" default:"development"`
+	SentryDSN   string `envconfig:"SENTRY_DSN"`
+}
+
+// Load reads Spec from the environment.
+func Load() (*Spec, error) {
+	var spec Spec
+	if err := envconfig.Process("", &spec); err != nil {
+		return nil, fmt.Errorf("config: load: %w", err)
+	}
+	return &spec, nil
+}
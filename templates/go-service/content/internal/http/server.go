@@ -0,0 +1,68 @@
+// Package http wires together the gin engine and the subsystems that
+// attach routes to it, and owns the HTTP server's start/stop lifecycle.
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+
+	"github.com/${{ values.repoUrl | parseRepoUrl | pick('owner') }}/${{ values.name }}/internal/config"
+)
+
+// Server owns the gin engine and the underlying net/http.Server.
+type Server struct {
+	log    *zap.Logger
+	engine *gin.Engine
+	srv    *http.Server
+}
+
+// New builds a Server with the base routes every scaffolded service
+// exposes (health check, Prometheus metrics). Subsystems register their
+// own routes onto Engine() before Start is called.
+func New(cfg *config.Spec, log *zap.Logger) *Server {
+	engine := gin.New()
+	engine.Use(gin.Recovery())
+
+	engine.GET("/healthz", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	return &Server{
+		log:    log,
+		engine: engine,
+		srv: &http.Server{
+			Addr:    fmt.Sprintf(":%d", cfg.Port),
+			Handler: engine,
+		},
+	}
+}
+
+// Engine returns the gin engine so subsystems can register routes on it
+// before Start is called.
+func (s *Server) Engine() *gin.Engine {
+	return s.engine
+}
+
+// Start begins serving HTTP in the background. It returns immediately;
+// fatal listener errors are logged rather than returned.
+func (s *Server) Start() {
+	go func() {
+		if err := s.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.log.Error("http server stopped unexpectedly", zap.Error(err))
+		}
+	}()
+}
+
+// Stop gracefully drains in-flight requests before shutting down.
+func (s *Server) Stop(ctx context.Context) error {
+	shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	return s.srv.Shutdown(shutdownCtx)
+}
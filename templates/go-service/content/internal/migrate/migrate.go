@@ -0,0 +1,108 @@
+// Package migrate runs the service's golang-migrate migrations, skipping
+// the run entirely when changeset.Match shows no migration files changed
+// since the last applied commit.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"go.uber.org/zap"
+
+	"github.com/${{ values.repoUrl | parseRepoUrl | pick('owner') }}/${{ values.name }}/pkg/changeset"
+)
+
+// reloadOnlySubsystems lists the platform.yaml subsystems Up recognizes
+// but can't act on in place: matching files are only logged, not
+// reloaded, so operators know a restart is still required.
+var reloadOnlySubsystems = []string{"http", "metrics"}
+
+// Runner drives migrate.Up(), gated by the changeset subsystem.
+type Runner struct {
+	dsn            string
+	migrationsPath string
+	repoDir        string
+	store          *changeset.Store
+	log            *zap.Logger
+}
+
+// NewRunner builds a Runner. migrationsPath is a "file://" source
+// directory (e.g. "migrations"); repoDir is the checkout changeset
+// diffs against.
+func NewRunner(dsn, migrationsPath, repoDir string, store *changeset.Store, log *zap.Logger) *Runner {
+	return &Runner{dsn: dsn, migrationsPath: migrationsPath, repoDir: repoDir, store: store, log: log}
+}
+
+// Up runs pending migrations, unless rules show the migrations/** glob
+// has no changed files since the last applied commit, in which case it
+// records the new HEAD and returns without running migrations. It is
+// also the entry point SIGHUP reload calls, so it additionally logs
+// whether any of reloadOnlySubsystems' globs matched.
+//
+// repoDir isn't always a git checkout (a typical deploy copies the
+// binary and migrations/ into an image with no .git directory). When
+// HeadSHA fails, the changeset gate is skipped entirely and migrations
+// run unconditionally rather than failing boot.
+func (r *Runner) Up(ctx context.Context, rules []changeset.Rule) error {
+	if err := r.store.EnsureSchema(ctx); err != nil {
+		return err
+	}
+
+	head, err := changeset.HeadSHA(ctx, r.repoDir)
+	if err != nil {
+		r.log.Warn("changeset: repoDir is not a git checkout, skipping changeset gate and running migrations unconditionally", zap.Error(err))
+		return r.runMigrations(ctx)
+	}
+
+	lastSHA, err := r.store.LastAppliedSHA(ctx)
+	if err != nil {
+		return err
+	}
+
+	if lastSHA == "" {
+		if err := r.runMigrations(ctx); err != nil {
+			return err
+		}
+		return r.store.SetLastAppliedSHA(ctx, head)
+	}
+
+	files, err := changeset.ChangedFiles(ctx, r.repoDir, lastSHA, head)
+	if err != nil {
+		return err
+	}
+	matched := changeset.Match(files, rules)
+
+	for _, subsystem := range reloadOnlySubsystems {
+		if matched[subsystem] {
+			r.log.Warn("changeset: subsystem has changed files but dynamic reload isn't implemented, a restart is required to pick them up",
+				zap.String("subsystem", subsystem))
+		}
+	}
+
+	if !matched["migrations"] {
+		r.log.Info("changeset: no migration files changed, skipping migrate.Up", zap.String("sha", head))
+		return r.store.SetLastAppliedSHA(ctx, head)
+	}
+
+	if err := r.runMigrations(ctx); err != nil {
+		return err
+	}
+	return r.store.SetLastAppliedSHA(ctx, head)
+}
+
+func (r *Runner) runMigrations(ctx context.Context) error {
+	m, err := migrate.New("file://"+r.migrationsPath, r.dsn)
+	if err != nil {
+		return fmt.Errorf("migrate: open: %w", err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migrate: up: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Package changeset decides which subsystems of a running service need
+// to react to a deploy by matching the files changed since the last
+// applied commit against a set of gitignore-style rules.
+package changeset
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule maps a single gitignore-style glob pattern to the subsystem key
+// that should react when a changed file matches it.
+type Rule struct {
+	Pattern   string `yaml:"pattern"`
+	Subsystem string `yaml:"subsystem"`
+}
+
+type rulesFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadRules reads the rule set from a platform.yaml-style file.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("changeset: read rules: %w", err)
+	}
+	var rf rulesFile
+	if err := yaml.Unmarshal(data, &rf); err != nil {
+		return nil, fmt.Errorf("changeset: parse rules: %w", err)
+	}
+	return rf.Rules, nil
+}
+
+// Match reports, for every subsystem referenced in rules, whether any of
+// the given files ends up matched once gitignore-style negation (`!`)
+// between rules targeting that subsystem is applied in order.
+func Match(files []string, rules []Rule) map[string]bool {
+	bySubsystem := make(map[string][]Rule)
+	for _, r := range rules {
+		bySubsystem[r.Subsystem] = append(bySubsystem[r.Subsystem], r)
+	}
+
+	var mu sync.Mutex
+	compiled := make(map[string]*pattern)
+	compile := func(raw string) *pattern {
+		mu.Lock()
+		defer mu.Unlock()
+		if p, ok := compiled[raw]; ok {
+			return p
+		}
+		p := compilePattern(raw)
+		compiled[raw] = p
+		return p
+	}
+
+	result := make(map[string]bool, len(bySubsystem))
+	for subsystem, subRules := range bySubsystem {
+		matched := false
+		for _, f := range files {
+			state := false
+			for _, r := range subRules {
+				if compile(r.Pattern).matches(f) {
+					state = !strings.HasPrefix(r.Pattern, "!")
+				}
+			}
+			if state {
+				matched = true
+				break
+			}
+		}
+		result[subsystem] = matched
+	}
+	return result
+}
+
+// pattern is a compiled gitignore-style glob.
+type pattern struct {
+	re *regexp.Regexp
+}
+
+func (p *pattern) matches(file string) bool {
+	return p.re.MatchString(file)
+}
+
+// compilePattern translates a single gitignore-style line (a leading `!`
+// is ignored here; Match applies the negation) into a regexp matching
+// repo-relative file paths. Supported syntax: `**` recursive wildcard,
+// `*`/`?` single-segment wildcards, a trailing `/` for directory-only
+// patterns, and root-anchoring when the pattern contains a non-trailing
+// `/`.
+func compilePattern(raw string) *pattern {
+	p := strings.TrimPrefix(raw, "!")
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !anchored {
+		sb.WriteString("(?:.*/)?")
+	}
+
+	runes := []rune(p)
+	for i := 0; i < len(runes); {
+		switch c := runes[i]; {
+		case c == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			if i < len(runes) && runes[i] == '/' {
+				// `**/` spans zero or more whole directories only.
+				i++
+				sb.WriteString("(?:.*/)?")
+			} else {
+				sb.WriteString(".*")
+			}
+		case c == '*':
+			sb.WriteString("[^/]*")
+			i++
+		case c == '?':
+			sb.WriteString("[^/]")
+			i++
+		case strings.ContainsRune(`.+()^$|{}[]\`, c):
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+			i++
+		default:
+			sb.WriteString(string(c))
+			i++
+		}
+	}
+
+	if dirOnly {
+		// A dir-only pattern never matches a changed file path equal to
+		// the directory name itself (git diff never reports a bare
+		// directory) - it only matches files underneath it.
+		sb.WriteString("/.*$")
+	} else {
+		sb.WriteString("$")
+	}
+
+	return &pattern{re: regexp.MustCompile(sb.String())}
+}
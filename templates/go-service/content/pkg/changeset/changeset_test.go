@@ -0,0 +1,88 @@
+package changeset
+
+import "testing"
+
+func TestCompilePatternMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		file    string
+		want    bool
+	}{
+		{"unanchored wildcard matches root file", "*.go", "main.go", true},
+		{"unanchored wildcard matches nested file", "*.go", "internal/config/config.go", true},
+		{"unanchored wildcard rejects non-match", "*.go", "main.txt", false},
+		{"anchored pattern matches only that path", "internal/http/server.go", "internal/http/server.go", true},
+		{"anchored pattern rejects sibling path", "internal/http/server.go", "internal/httpx/server.go", false},
+		{"anchored recursive wildcard matches nested file", "migrations/**", "migrations/sub/0002.sql", true},
+		{"anchored recursive wildcard matches direct child", "migrations/**", "migrations/0001.sql", true},
+		{"anchored recursive wildcard rejects unrelated prefix", "migrations/**", "internal/migrations/x.go", false},
+		{"leading recursive wildcard matches any depth", "**/README.md", "README.md", true},
+		{"leading recursive wildcard matches nested", "**/README.md", "a/b/README.md", true},
+		{"dir-only pattern matches file underneath", "node_modules/", "node_modules/foo.js", true},
+		{"dir-only pattern matches nested file underneath", "node_modules/", "node_modules/sub/foo.js", true},
+		{"dir-only pattern rejects same-named file with no trailing slash", "node_modules/", "node_modules", false},
+		{"dir-only pattern rejects unrelated sibling", "node_modules/", "othermodules/foo.js", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compilePattern(tt.pattern).matches(tt.file)
+			if got != tt.want {
+				t.Errorf("compilePattern(%q).matches(%q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	rules := []Rule{
+		{Pattern: "migrations/**", Subsystem: "migrations"},
+		{Pattern: "internal/http/**", Subsystem: "http"},
+		{Pattern: "*.md", Subsystem: "docs"},
+		{Pattern: "!README.md", Subsystem: "docs"},
+	}
+
+	tests := []struct {
+		name  string
+		files []string
+		want  map[string]bool
+	}{
+		{
+			name:  "migration file changed",
+			files: []string{"migrations/0001_init.up.sql"},
+			want:  map[string]bool{"migrations": true, "http": false, "docs": false},
+		},
+		{
+			name:  "http file changed",
+			files: []string{"internal/http/server.go"},
+			want:  map[string]bool{"migrations": false, "http": true, "docs": false},
+		},
+		{
+			name:  "negated rule un-matches README but not other markdown",
+			files: []string{"README.md", "CHANGELOG.md"},
+			want:  map[string]bool{"migrations": false, "http": false, "docs": true},
+		},
+		{
+			name:  "negated rule leaves docs false when only README changed",
+			files: []string{"README.md"},
+			want:  map[string]bool{"migrations": false, "http": false, "docs": false},
+		},
+		{
+			name:  "no files changed",
+			files: nil,
+			want:  map[string]bool{"migrations": false, "http": false, "docs": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Match(tt.files, rules)
+			for subsystem, want := range tt.want {
+				if got[subsystem] != want {
+					t.Errorf("Match(%v)[%q] = %v, want %v", tt.files, subsystem, got[subsystem], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package changeset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ChangedFiles returns the repo-relative paths that differ between fromSHA
+// and toSHA, as reported by `git diff --name-only`. Callers that have no
+// recorded fromSHA yet (first boot after a fresh clone) should skip this
+// and run every subsystem unconditionally instead of calling it with an
+// empty SHA.
+func ChangedFiles(ctx context.Context, repoDir, fromSHA, toSHA string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "diff", "--name-only", fromSHA, toSHA)
+	cmd.Dir = repoDir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("changeset: git diff %s..%s: %w: %s", fromSHA, toSHA, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var files []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// HeadSHA resolves the current commit SHA of repoDir's checkout.
+func HeadSHA(ctx context.Context, repoDir string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "HEAD")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("changeset: resolve HEAD: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,60 @@
+package changeset
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Store persists the last commit SHA that changeset-gated subsystems
+// were applied against, so restarts and SIGHUP reloads only need to look
+// at what changed since then.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore wraps an existing *sql.DB. It does not take ownership of the
+// connection pool.
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// EnsureSchema creates the backing table if it does not already exist.
+func (s *Store) EnsureSchema(ctx context.Context) error {
+	const ddl = `
+CREATE TABLE IF NOT EXISTS changeset_state (
+	id smallint PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+	last_applied_sha text NOT NULL
+)`
+	if _, err := s.db.ExecContext(ctx, ddl); err != nil {
+		return fmt.Errorf("changeset: ensure schema: %w", err)
+	}
+	return nil
+}
+
+// LastAppliedSHA returns the previously recorded SHA, or "" if none has
+// been recorded yet.
+func (s *Store) LastAppliedSHA(ctx context.Context) (string, error) {
+	var sha string
+	err := s.db.QueryRowContext(ctx, `SELECT last_applied_sha FROM changeset_state WHERE id = 1`).Scan(&sha)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("changeset: read last applied sha: %w", err)
+	}
+	return sha, nil
+}
+
+// SetLastAppliedSHA records sha as the last commit changeset-gated
+// subsystems were applied against.
+func (s *Store) SetLastAppliedSHA(ctx context.Context, sha string) error {
+	const upsert = `
+INSERT INTO changeset_state (id, last_applied_sha) VALUES (1, $1)
+ON CONFLICT (id) DO UPDATE SET last_applied_sha = EXCLUDED.last_applied_sha`
+	if _, err := s.db.ExecContext(ctx, upsert, sha); err != nil {
+		return fmt.Errorf("changeset: write last applied sha: %w", err)
+	}
+	return nil
+}
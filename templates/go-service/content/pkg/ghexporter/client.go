@@ -0,0 +1,84 @@
+package ghexporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// client is a minimal GitHub REST client that caches responses by ETag
+// so repeated polls of an unchanged resource don't count against the
+// rate limit.
+type client struct {
+	http  *http.Client
+	token string
+
+	mu    sync.Mutex
+	etags map[string]string
+	bodys map[string][]byte
+}
+
+func newClient(cfg *Config) *client {
+	return &client{
+		http:  &http.Client{},
+		token: cfg.Token,
+		etags: make(map[string]string),
+		bodys: make(map[string][]byte),
+	}
+}
+
+// getJSON fetches url and decodes the JSON body into out. If the server
+// responds 304 Not Modified (because we sent a cached ETag), out is
+// decoded from the body cached from the last successful response, since
+// a 304 carries no body.
+func (c *client) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("ghexporter: build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	c.mu.Lock()
+	if etag, ok := c.etags[url]; ok {
+		req.Header.Set("If-None-Match", etag)
+	}
+	c.mu.Unlock()
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("ghexporter: request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("ghexporter: decode %s: %w", url, err)
+		}
+		// Re-encode so a future 304 can be served from cache without
+		// holding a second, already-decoded copy around.
+		body, _ := json.Marshal(out)
+		c.mu.Lock()
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			c.etags[url] = etag
+		}
+		c.bodys[url] = body
+		c.mu.Unlock()
+		return nil
+	case http.StatusNotModified:
+		c.mu.Lock()
+		cached := c.bodys[url]
+		c.mu.Unlock()
+		if cached == nil {
+			return fmt.Errorf("ghexporter: 304 for %s with no cached body", url)
+		}
+		return json.Unmarshal(cached, out)
+	default:
+		return fmt.Errorf("ghexporter: %s: unexpected status %s", url, resp.Status)
+	}
+}
@@ -0,0 +1,26 @@
+package ghexporter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config is the envconfig-driven settings for the ghexporter subsystem,
+// prefixed GHEXPORTER_ in the environment.
+type Config struct {
+	Enabled  bool          `envconfig:"ENABLED" default:"false"`
+	Repo     string        `envconfig:"REPO"`
+	Token    string        `envconfig:"TOKEN"`
+	Interval time.Duration `envconfig:"INTERVAL" default:"5m"`
+}
+
+// LoadConfig reads Config from the environment.
+func LoadConfig() (*Config, error) {
+	var cfg Config
+	if err := envconfig.Process("ghexporter", &cfg); err != nil {
+		return nil, fmt.Errorf("ghexporter: load config: %w", err)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,195 @@
+// Package ghexporter registers Prometheus collectors that expose
+// GitHub statistics about the service's own source repo, so platform
+// teams can correlate deploy health with upstream repo activity across
+// every scaffolded service.
+package ghexporter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"go.uber.org/zap"
+)
+
+// maxReleasesTracked bounds how many github_release_age_seconds{tag=}
+// series a single repo can produce, to keep label cardinality in check.
+const maxReleasesTracked = 5
+
+// Exporter polls the GitHub REST API on an interval and updates the
+// package's Prometheus collectors, which are registered globally in
+// metrics.go so they appear on the scaffold's existing /metrics handler.
+type Exporter struct {
+	cfg    *Config
+	log    *zap.Logger
+	client *client
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New builds an Exporter. Call Start to begin the background poll loop.
+func New(cfg *Config, log *zap.Logger) *Exporter {
+	return &Exporter{cfg: cfg, log: log, client: newClient(cfg)}
+}
+
+// Start begins the background poll loop. It is a no-op if the subsystem
+// is disabled. Calling Start twice is not supported.
+func (e *Exporter) Start(ctx context.Context) {
+	if !e.cfg.Enabled {
+		e.log.Info("ghexporter disabled, skipping poll loop")
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+	e.done = make(chan struct{})
+
+	go func() {
+		defer close(e.done)
+		ticker := time.NewTicker(e.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			e.scrape(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop halts the poll loop and waits for it to exit.
+func (e *Exporter) Stop() {
+	if e.cancel == nil {
+		return
+	}
+	e.cancel()
+	<-e.done
+}
+
+func (e *Exporter) scrape(ctx context.Context) {
+	if err := e.scrapeRepo(ctx); err != nil {
+		e.fail("repo", err)
+	}
+	if err := e.scrapeOpenCounts(ctx); err != nil {
+		e.fail("open_counts", err)
+	}
+	if err := e.scrapeReleases(ctx); err != nil {
+		e.fail("releases", err)
+	}
+	if err := e.scrapeWorkflowRuns(ctx); err != nil {
+		e.fail("workflow_runs", err)
+	}
+}
+
+func (e *Exporter) fail(stage string, err error) {
+	scrapeErrorsTotal.Inc()
+	e.log.Error("ghexporter: scrape failed", zap.String("stage", stage), zap.Error(err))
+	sentry.CaptureException(fmt.Errorf("ghexporter: %s: %w", stage, err))
+
+	switch stage {
+	case "repo":
+		repoStars.Set(0)
+		repoForks.Set(0)
+	case "open_counts":
+		repoOpenIssues.Set(0)
+		repoOpenPRs.Set(0)
+	case "releases":
+		releaseAgeSeconds.Reset()
+	case "workflow_runs":
+		workflowRunStatus.Reset()
+	}
+}
+
+type repoResponse struct {
+	StargazersCount int `json:"stargazers_count"`
+	ForksCount      int `json:"forks_count"`
+}
+
+func (e *Exporter) scrapeRepo(ctx context.Context) error {
+	var repo repoResponse
+	url := fmt.Sprintf("https://api.github.com/repos/%s", e.cfg.Repo)
+	if err := e.client.getJSON(ctx, url, &repo); err != nil {
+		return err
+	}
+	repoStars.Set(float64(repo.StargazersCount))
+	repoForks.Set(float64(repo.ForksCount))
+	return nil
+}
+
+type searchResponse struct {
+	TotalCount int `json:"total_count"`
+}
+
+func (e *Exporter) scrapeOpenCounts(ctx context.Context) error {
+	var issues searchResponse
+	issuesURL := fmt.Sprintf("https://api.github.com/search/issues?q=repo:%s+type:issue+state:open", e.cfg.Repo)
+	if err := e.client.getJSON(ctx, issuesURL, &issues); err != nil {
+		return err
+	}
+
+	var prs searchResponse
+	prsURL := fmt.Sprintf("https://api.github.com/search/issues?q=repo:%s+type:pr+state:open", e.cfg.Repo)
+	if err := e.client.getJSON(ctx, prsURL, &prs); err != nil {
+		return err
+	}
+
+	repoOpenIssues.Set(float64(issues.TotalCount))
+	repoOpenPRs.Set(float64(prs.TotalCount))
+	return nil
+}
+
+type releaseResponse struct {
+	TagName     string    `json:"tag_name"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+func (e *Exporter) scrapeReleases(ctx context.Context) error {
+	var releases []releaseResponse
+	url := fmt.Sprintf("https://api.github.com/repos/%s/releases?per_page=%d", e.cfg.Repo, maxReleasesTracked)
+	if err := e.client.getJSON(ctx, url, &releases); err != nil {
+		return err
+	}
+
+	// Reset first so a tag that ages out of the top maxReleasesTracked
+	// releases doesn't keep serving a stale series forever.
+	releaseAgeSeconds.Reset()
+
+	now := time.Now()
+	for _, r := range releases {
+		releaseAgeSeconds.WithLabelValues(r.TagName).Set(now.Sub(r.PublishedAt).Seconds())
+	}
+	return nil
+}
+
+type workflowRunsResponse struct {
+	WorkflowRuns []struct {
+		Name       string `json:"name"`
+		Conclusion string `json:"conclusion"`
+	} `json:"workflow_runs"`
+}
+
+func (e *Exporter) scrapeWorkflowRuns(ctx context.Context) error {
+	var resp workflowRunsResponse
+	url := fmt.Sprintf("https://api.github.com/repos/%s/actions/runs?per_page=20", e.cfg.Repo)
+	if err := e.client.getJSON(ctx, url, &resp); err != nil {
+		return err
+	}
+
+	latestConclusion := make(map[string]string)
+	for _, run := range resp.WorkflowRuns {
+		if _, seen := latestConclusion[run.Name]; !seen {
+			latestConclusion[run.Name] = run.Conclusion
+		}
+	}
+	// Reset first so a workflow's previous conclusion doesn't keep
+	// serving alongside its new one once the run status flips.
+	workflowRunStatus.Reset()
+
+	for workflow, conclusion := range latestConclusion {
+		workflowRunStatus.WithLabelValues(workflow, conclusion).Set(1)
+	}
+	return nil
+}
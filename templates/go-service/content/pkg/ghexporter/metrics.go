@@ -0,0 +1,38 @@
+package ghexporter
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	repoStars = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "github_repo_stars",
+		Help: "Stargazer count of the service's own source repo.",
+	})
+	repoForks = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "github_repo_forks",
+		Help: "Fork count of the service's own source repo.",
+	})
+	repoOpenIssues = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "github_repo_open_issues",
+		Help: "Open issue count of the service's own source repo, excluding pull requests.",
+	})
+	repoOpenPRs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "github_repo_open_prs",
+		Help: "Open pull request count of the service's own source repo.",
+	})
+	releaseAgeSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "github_release_age_seconds",
+		Help: "Seconds since each of the repo's most recent releases was published.",
+	}, []string{"tag"})
+	workflowRunStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "github_workflow_run_status",
+		Help: "Set to 1 for the conclusion of each workflow's most recent run; other conclusions have no series.",
+	}, []string{"workflow", "conclusion"})
+	scrapeErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "github_exporter_scrape_errors_total",
+		Help: "Number of failed GitHub API scrapes by the ghexporter subsystem.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(repoStars, repoForks, repoOpenIssues, repoOpenPRs, releaseAgeSeconds, workflowRunStatus, scrapeErrorsTotal)
+}
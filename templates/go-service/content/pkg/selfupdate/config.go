@@ -0,0 +1,34 @@
+package selfupdate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Channel selects which GitHub releases are eligible for self-update.
+type Channel string
+
+const (
+	ChannelStable     Channel = "stable"
+	ChannelPrerelease Channel = "prerelease"
+)
+
+// Config is the envconfig-driven settings for the selfupdate subsystem,
+// prefixed SELFUPDATE_ in the environment.
+type Config struct {
+	Enabled  bool          `envconfig:"ENABLED" default:"false"`
+	Repo     string        `envconfig:"REPO"`
+	Interval time.Duration `envconfig:"INTERVAL" default:"1h"`
+	Channel  Channel       `envconfig:"CHANNEL" default:"stable"`
+}
+
+// LoadConfig reads Config from the environment.
+func LoadConfig() (*Config, error) {
+	var cfg Config
+	if err := envconfig.Process("selfupdate", &cfg); err != nil {
+		return nil, fmt.Errorf("selfupdate: load config: %w", err)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package selfupdate
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// execSelf replaces the current process image with the freshly swapped
+// binary at path, preserving argv and the environment.
+func execSelf(path string) error {
+	if err := syscall.Exec(path, os.Args, os.Environ()); err != nil {
+		return fmt.Errorf("selfupdate: exec: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+//go:build windows
+
+package selfupdate
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// execSelf has no process-image-replacing equivalent on Windows, so it
+// spawns the new binary as a child and exits the current process.
+func execSelf(path string) error {
+	cmd := exec.Command(path, os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("selfupdate: spawn: %w", err)
+	}
+	os.Exit(0)
+	return nil
+}
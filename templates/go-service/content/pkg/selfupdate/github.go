@@ -0,0 +1,116 @@
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+)
+
+// release is the subset of the GitHub Releases API response we care
+// about.
+type release struct {
+	TagName    string  `json:"tag_name"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []asset `json:"assets"`
+}
+
+type asset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// latestRelease returns the newest release on the given channel for
+// cfg.Repo, or nil if none matches.
+func latestRelease(ctx context.Context, client *http.Client, cfg *Config) (*release, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/releases", cfg.Repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("selfupdate: build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("selfupdate: list releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("selfupdate: list releases: unexpected status %s", resp.Status)
+	}
+
+	var releases []release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("selfupdate: decode releases: %w", err)
+	}
+
+	for i := range releases {
+		if cfg.Channel == ChannelStable && releases[i].Prerelease {
+			continue
+		}
+		return &releases[i], nil
+	}
+	return nil, nil
+}
+
+// assetFor picks the release asset matching this platform's GOOS/GOARCH,
+// e.g. "myservice_linux_amd64".
+func assetFor(r *release) (*asset, error) {
+	suffix := fmt.Sprintf("_%s_%s", runtime.GOOS, runtime.GOARCH)
+	for i := range r.Assets {
+		if strings.Contains(r.Assets[i].Name, suffix) && !strings.HasSuffix(r.Assets[i].Name, ".sha256") {
+			return &r.Assets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("selfupdate: no asset for %s", suffix)
+}
+
+// checksumFor locates the matching ".sha256" asset for a binary asset,
+// downloads it and returns the expected hex digest.
+func checksumFor(ctx context.Context, client *http.Client, r *release, bin *asset) (string, error) {
+	for i := range r.Assets {
+		if r.Assets[i].Name == bin.Name+".sha256" {
+			body, err := download(ctx, client, r.Assets[i].BrowserDownloadURL)
+			if err != nil {
+				return "", err
+			}
+			fields := strings.Fields(string(body))
+			if len(fields) == 0 {
+				return "", fmt.Errorf("selfupdate: checksum asset %s is empty", r.Assets[i].Name)
+			}
+			return strings.TrimSpace(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("selfupdate: no checksum asset for %s", bin.Name)
+}
+
+func download(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("selfupdate: build download request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("selfupdate: download: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("selfupdate: download: unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func verifyChecksum(data []byte, wantHex string) error {
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	if got != wantHex {
+		return fmt.Errorf("selfupdate: checksum mismatch: got %s want %s", got, wantHex)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package selfupdate
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	checksTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "selfupdate_checks_total",
+		Help: "Number of times the selfupdate subsystem polled GitHub Releases for a newer version.",
+	})
+	successTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "selfupdate_success_total",
+		Help: "Number of successful in-place binary updates.",
+	})
+	failureTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "selfupdate_failures_total",
+		Help: "Number of failed self-update attempts, by stage.",
+	}, []string{"stage"})
+)
+
+func init() {
+	prometheus.MustRegister(checksTotal, successTotal, failureTotal)
+}
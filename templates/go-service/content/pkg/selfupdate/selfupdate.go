@@ -0,0 +1,196 @@
+// Package selfupdate lets a scaffolded service check GitHub Releases for
+// a newer build of itself and, when enabled, replace its own binary in
+// place without waiting on a redeploy pipeline.
+package selfupdate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/getsentry/sentry-go"
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+
+	"github.com/${{ values.repoUrl | parseRepoUrl | pick('owner') }}/${{ values.name }}/internal/config"
+)
+
+// Updater periodically polls GitHub Releases and, when enabled, applies
+// newer builds in place.
+type Updater struct {
+	cfg    *Config
+	log    *zap.Logger
+	client *http.Client
+
+	mu     sync.RWMutex
+	latest string
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New builds an Updater. Call Start to begin the background poll loop.
+func New(cfg *Config, log *zap.Logger) *Updater {
+	return &Updater{
+		cfg:    cfg,
+		log:    log,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Start begins the background poll loop. It is a no-op if the subsystem
+// is disabled. Calling Start twice is not supported.
+func (u *Updater) Start(ctx context.Context) {
+	if !u.cfg.Enabled {
+		u.log.Info("selfupdate disabled, skipping poll loop")
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	u.cancel = cancel
+	u.done = make(chan struct{})
+
+	go func() {
+		defer close(u.done)
+		ticker := time.NewTicker(u.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			u.check(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop halts the poll loop and waits for it to exit.
+func (u *Updater) Stop() {
+	if u.cancel == nil {
+		return
+	}
+	u.cancel()
+	<-u.done
+}
+
+// Status is the payload served by the /admin/selfupdate endpoint.
+type Status struct {
+	Current   string `json:"current"`
+	Latest    string `json:"latest"`
+	Available bool   `json:"available"`
+}
+
+// RegisterRoutes attaches the admin status endpoint to the gin engine.
+func (u *Updater) RegisterRoutes(r gin.IRouter) {
+	r.GET("/admin/selfupdate", func(c *gin.Context) {
+		u.mu.RLock()
+		latest := u.latest
+		u.mu.RUnlock()
+		c.JSON(http.StatusOK, Status{
+			Current:   config.Version,
+			Latest:    latest,
+			Available: latest != "" && semver.IsValid(latest) && semver.IsValid(config.Version) && semver.Compare(latest, config.Version) > 0,
+		})
+	})
+}
+
+func (u *Updater) check(ctx context.Context) {
+	checksTotal.Inc()
+
+	rel, err := latestRelease(ctx, u.client, u.cfg)
+	if err != nil {
+		failureTotal.WithLabelValues("check").Inc()
+		u.log.Warn("selfupdate: check failed", zap.Error(err))
+		return
+	}
+	if rel == nil {
+		return
+	}
+
+	u.mu.Lock()
+	u.latest = rel.TagName
+	u.mu.Unlock()
+
+	if !semver.IsValid(rel.TagName) || !semver.IsValid(config.Version) {
+		u.log.Warn("selfupdate: skipping non-semver tag comparison",
+			zap.String("current", config.Version), zap.String("latest", rel.TagName))
+		return
+	}
+	if semver.Compare(rel.TagName, config.Version) <= 0 {
+		return
+	}
+
+	u.log.Info("selfupdate: newer release available", zap.String("current", config.Version), zap.String("latest", rel.TagName))
+	if err := u.apply(ctx, rel); err != nil {
+		failureTotal.WithLabelValues("apply").Inc()
+		u.log.Error("selfupdate: apply failed", zap.Error(err))
+		sentry.CaptureMessage(fmt.Sprintf("selfupdate: update %s -> %s failed: %v", config.Version, rel.TagName, err))
+		return
+	}
+	successTotal.Inc()
+}
+
+// apply downloads the asset matching this platform, verifies its
+// checksum, and atomically swaps it in for the running executable.
+func (u *Updater) apply(ctx context.Context, rel *release) error {
+	bin, err := assetFor(rel)
+	if err != nil {
+		return err
+	}
+	wantSum, err := checksumFor(ctx, u.client, rel, bin)
+	if err != nil {
+		return err
+	}
+	data, err := download(ctx, u.client, bin.BrowserDownloadURL)
+	if err != nil {
+		return err
+	}
+	if err := verifyChecksum(data, wantSum); err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("selfupdate: resolve executable: %w", err)
+	}
+
+	// Back up the running binary before swapping so a failed exec can be
+	// rolled back instead of leaving an unexecuted binary on disk that
+	// would silently start running on the next process restart.
+	backup := exe + ".prev"
+	if err := os.Rename(exe, backup); err != nil {
+		return fmt.Errorf("selfupdate: back up current binary: %w", err)
+	}
+
+	tmp := exe + ".next"
+	if err := os.WriteFile(tmp, data, 0o755); err != nil {
+		if rbErr := os.Rename(backup, exe); rbErr != nil {
+			return fmt.Errorf("selfupdate: write new binary: %w (rollback also failed: %v)", err, rbErr)
+		}
+		return fmt.Errorf("selfupdate: write new binary: %w", err)
+	}
+	if err := os.Rename(tmp, exe); err != nil {
+		if rbErr := os.Rename(backup, exe); rbErr != nil {
+			return fmt.Errorf("selfupdate: swap binary: %w (rollback also failed: %v)", err, rbErr)
+		}
+		return fmt.Errorf("selfupdate: swap binary: %w", err)
+	}
+
+	if err := execSelf(exe); err != nil {
+		if rbErr := os.Rename(backup, exe); rbErr != nil {
+			return fmt.Errorf("selfupdate: exec new binary: %w (rollback also failed: %v)", err, rbErr)
+		}
+		return fmt.Errorf("selfupdate: exec new binary failed, rolled back to previous binary: %w", err)
+	}
+
+	// Unreachable on success: execSelf replaces this process image on
+	// unix and exits it on windows. Left here so the backup is cleaned
+	// up if a future platform's execSelf ever returns nil without doing
+	// either.
+	_ = os.Remove(backup)
+	return nil
+}